@@ -0,0 +1,26 @@
+package matrix
+
+// gemm32 and gemm64 are the low-level matrix-multiply kernels used by Mul.
+// dst, a and b are row-major buffers; dst must already be sized rows*cols.
+// Swapping in a different gemm32/gemm64 (e.g. gonum's blas64.Gemm, or an
+// external CBLAS binding) lets callers change the Mul backend without
+// touching anything above this file.
+type gemm32 func(dst, a, b []uint32, rows, inner, cols uint64)
+type gemm64 func(dst, a, b []uint64, rows, inner, cols uint64)
+
+// mul32 and mul64 hold the active kernels. The build-tagged files
+// matrix_cgo.go and matrix_noasm.go each set these in an init() to the
+// implementation matching how the package was built.
+var mul32 gemm32
+var mul64 gemm64
+
+// SetGEMM32 overrides the uint32 matrix-multiply kernel used by Mul, e.g.
+// to route through gonum's blas64 package or an external CBLAS.
+func SetGEMM32(f gemm32) {
+	mul32 = f
+}
+
+// SetGEMM64 overrides the uint64 matrix-multiply kernel used by Mul.
+func SetGEMM64(f gemm64) {
+	mul64 = f
+}
@@ -1,9 +1,5 @@
 package matrix
 
-// #cgo CFLAGS: -O3 -march=native
-// #include "matrix.h"
-import "C"
-
 import (
 	"crypto/rand"
 	"fmt"
@@ -15,9 +11,8 @@ import (
   "github.com/henrycg/simplepir/lwe"
 )
 
-type Elem32 = C.Elem32
-type Elem64 = C.Elem64
-
+// Elem32 and Elem64 are defined in matrix_cgo.go or matrix_noasm.go,
+// whichever the build tags select.
 type Elem interface {
     Elem32 | Elem64
 }
@@ -191,6 +186,83 @@ func (m *Matrix[T]) Equals(n *Matrix[T]) bool {
 }
 
 
+// Mul returns a*b, dispatching to the uint32 or uint64 GEMM kernel
+// selected at build time (see matrix_cgo.go / matrix_noasm.go), or to
+// whatever kernel was last installed via SetGEMM32/SetGEMM64.
+func Mul[T Elem](a, b *Matrix[T]) *Matrix[T] {
+	if a.cols != b.rows {
+		panic("Dimension mismatch")
+	}
+
+	out := New[T](a.rows, b.cols)
+	if a.Is32Bit() {
+		ab := toUint32(a.data)
+		bb := toUint32(b.data)
+		ob := make([]uint32, len(out.data))
+		mul32(ob, ab, bb, a.rows, a.cols, b.cols)
+		fromUint32(ob, out.data)
+	} else {
+		ab := toUint64(a.data)
+		bb := toUint64(b.data)
+		ob := make([]uint64, len(out.data))
+		mul64(ob, ab, bb, a.rows, a.cols, b.cols)
+		fromUint64(ob, out.data)
+	}
+	return out
+}
+
+func (m *Matrix[T]) Add(b *Matrix[T]) {
+	if m.rows != b.rows || m.cols != b.cols {
+		panic("Dimension mismatch")
+	}
+	for i := 0; i < len(m.data); i++ {
+		m.data[i] += b.data[i]
+	}
+}
+
+func (m *Matrix[T]) Sub(b *Matrix[T]) {
+	if m.rows != b.rows || m.cols != b.cols {
+		panic("Dimension mismatch")
+	}
+	for i := 0; i < len(m.data); i++ {
+		m.data[i] -= b.data[i]
+	}
+}
+
+func (m *Matrix[T]) MulConst(c T) {
+	for i := 0; i < len(m.data); i++ {
+		m.data[i] *= c
+	}
+}
+
+func toUint32[T Elem](in []T) []uint32 {
+	out := make([]uint32, len(in))
+	for i := range in {
+		out[i] = uint32(in[i])
+	}
+	return out
+}
+
+func toUint64[T Elem](in []T) []uint64 {
+	out := make([]uint64, len(in))
+	for i := range in {
+		out[i] = uint64(in[i])
+	}
+	return out
+}
+
+func fromUint32[T Elem](in []uint32, out []T) {
+	for i := range in {
+		out[i] = T(in[i])
+	}
+}
+
+func fromUint64[T Elem](in []uint64, out []T) {
+	for i := range in {
+		out[i] = T(in[i])
+	}
+}
+
 func Gaussian[T Elem](src IoRandSource, rows, cols uint64) *Matrix[T] {
 	out := New[T](rows, cols)
   samplef := lwe.GaussSample32
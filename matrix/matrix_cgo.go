@@ -0,0 +1,38 @@
+//go:build cgo && !noasm
+
+package matrix
+
+// #cgo CFLAGS: -O3 -march=native
+// #include "matrix.h"
+import "C"
+import "unsafe"
+
+type Elem32 = C.Elem32
+type Elem64 = C.Elem64
+
+func init() {
+	mul32 = cgoMul32
+	mul64 = cgoMul64
+}
+
+func cgoMul32(dst, a, b []uint32, rows, inner, cols uint64) {
+	if len(a) == 0 || len(b) == 0 {
+		return
+	}
+	C.matrix_mul_32(
+		(*C.Elem32)(unsafe.Pointer(&dst[0])),
+		(*C.Elem32)(unsafe.Pointer(&a[0])),
+		(*C.Elem32)(unsafe.Pointer(&b[0])),
+		C.uint64_t(rows), C.uint64_t(inner), C.uint64_t(cols))
+}
+
+func cgoMul64(dst, a, b []uint64, rows, inner, cols uint64) {
+	if len(a) == 0 || len(b) == 0 {
+		return
+	}
+	C.matrix_mul_64(
+		(*C.Elem64)(unsafe.Pointer(&dst[0])),
+		(*C.Elem64)(unsafe.Pointer(&a[0])),
+		(*C.Elem64)(unsafe.Pointer(&b[0])),
+		C.uint64_t(rows), C.uint64_t(inner), C.uint64_t(cols))
+}
@@ -0,0 +1,44 @@
+//go:build !cgo || noasm
+
+package matrix
+
+// Pure-Go fallback, selected whenever cgo is unavailable (cross-compiling,
+// CGO_ENABLED=0) or the noasm build tag is set. Elem32/Elem64 are plain Go
+// integer types here rather than the C types matrix_cgo.go uses, but every
+// other code path (New, Rand, Gaussian, SecretLHE, ...) is generic over Elem
+// and works identically against either backend.
+type Elem32 uint32
+type Elem64 uint64
+
+func init() {
+	mul32 = goMul32
+	mul64 = goMul64
+}
+
+func goMul32(dst, a, b []uint32, rows, inner, cols uint64) {
+	for i := uint64(0); i < rows; i++ {
+		for k := uint64(0); k < inner; k++ {
+			av := a[i*inner+k]
+			if av == 0 {
+				continue
+			}
+			for j := uint64(0); j < cols; j++ {
+				dst[i*cols+j] += av * b[k*cols+j]
+			}
+		}
+	}
+}
+
+func goMul64(dst, a, b []uint64, rows, inner, cols uint64) {
+	for i := uint64(0); i < rows; i++ {
+		for k := uint64(0); k < inner; k++ {
+			av := a[i*inner+k]
+			if av == 0 {
+				continue
+			}
+			for j := uint64(0); j < cols; j++ {
+				dst[i*cols+j] += av * b[k*cols+j]
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package matrix
+
+import "testing"
+
+func TestMulAddSubMulConst64(t *testing.T) {
+	a := New[Elem64](2, 3)
+	b := New[Elem64](3, 2)
+	for i := uint64(0); i < 2; i++ {
+		for j := uint64(0); j < 3; j++ {
+			a.Set(i*3+j+1, i, j)
+		}
+	}
+	for i := uint64(0); i < 3; i++ {
+		for j := uint64(0); j < 2; j++ {
+			b.Set(i*2+j+1, i, j)
+		}
+	}
+
+	got := Mul(a, b)
+	want := [2][2]uint64{{22, 28}, {49, 64}}
+	for i := range want {
+		for j := range want[i] {
+			if v := got.Get(uint64(i), uint64(j)); v != want[i][j] {
+				t.Fatalf("Mul[%d][%d] = %d, want %d", i, j, v, want[i][j])
+			}
+		}
+	}
+
+	sum := got.Copy()
+	sum.Add(got)
+	for i := range want {
+		for j := range want[i] {
+			if v := sum.Get(uint64(i), uint64(j)); v != 2*want[i][j] {
+				t.Fatalf("Add[%d][%d] = %d, want %d", i, j, v, 2*want[i][j])
+			}
+		}
+	}
+
+	diff := sum.Copy()
+	diff.Sub(got)
+	if !diff.Equals(got) {
+		t.Fatalf("Sub did not undo Add")
+	}
+
+	scaled := got.Copy()
+	scaled.MulConst(3)
+	for i := range want {
+		for j := range want[i] {
+			if v := scaled.Get(uint64(i), uint64(j)); v != 3*want[i][j] {
+				t.Fatalf("MulConst[%d][%d] = %d, want %d", i, j, v, 3*want[i][j])
+			}
+		}
+	}
+}
+
+// TestMulUsesInstalledGEMM64 checks that Mul dispatches to whatever kernel
+// is installed, rather than a hardcoded backend. Whichever of matrix_cgo.go
+// / matrix_noasm.go is selected by build tags sets the default kernel in
+// its init(); SetGEMM64 must still be able to override it, which is what
+// lets a caller route through gonum's blas64 or an external CBLAS.
+func TestMulUsesInstalledGEMM64(t *testing.T) {
+	orig := mul64
+	defer func() { mul64 = orig }()
+
+	called := false
+	SetGEMM64(func(dst, a, b []uint64, rows, inner, cols uint64) {
+		called = true
+		for i := range dst {
+			dst[i] = 42
+		}
+	})
+
+	a := New[Elem64](1, 1)
+	b := New[Elem64](1, 1)
+	out := Mul(a, b)
+
+	if !called {
+		t.Fatalf("Mul did not invoke the installed GEMM64 kernel")
+	}
+	if out.Get(0, 0) != 42 {
+		t.Fatalf("Mul result = %d, want 42 from the installed kernel", out.Get(0, 0))
+	}
+}
@@ -0,0 +1,78 @@
+package matrix
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+)
+
+// ctrSource turns an AES-256-CTR keystream into an IoRandSource, so it can
+// stand in for crypto/rand.Reader wherever Rand or Gaussian expect one.
+// Re-using the same seed always produces the same stream of values.
+type ctrSource struct {
+	stream cipher.Stream
+}
+
+func newCTRSource(seed *[32]byte) *ctrSource {
+	block, err := aes.NewCipher(seed[:])
+	if err != nil {
+		panic(err)
+	}
+	return &ctrSource{stream: cipher.NewCTR(block, make([]byte, aes.BlockSize))}
+}
+
+func (c *ctrSource) Read(p []byte) (int, error) {
+	zero := make([]byte, len(p))
+	c.stream.XORKeyStream(p, zero)
+	return len(p), nil
+}
+
+func (c *ctrSource) Uint64() uint64 {
+	var buf [8]byte
+	c.Read(buf[:])
+	return binary.LittleEndian.Uint64(buf[:])
+}
+
+func (c *ctrSource) Int63() int64 {
+	return int64(c.Uint64() >> 1)
+}
+
+func (c *ctrSource) Seed(int64) {}
+
+// cryptoRandSource adapts crypto/rand.Reader to IoRandSource, since Rand
+// requires an mrand.Source64 in addition to io.Reader.
+type cryptoRandSource struct{}
+
+func (cryptoRandSource) Read(p []byte) (int, error) {
+	return rand.Read(p)
+}
+
+func (s cryptoRandSource) Uint64() uint64 {
+	var buf [8]byte
+	s.Read(buf[:])
+	return binary.LittleEndian.Uint64(buf[:])
+}
+
+func (s cryptoRandSource) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+func (cryptoRandSource) Seed(int64) {}
+
+// RandFromSeed expands a 32-byte seed into a uniform-mod-q matrix by
+// running Rand over an AES-256-CTR keystream instead of crypto/rand.Reader;
+// Rand's per-element crypto/rand.Int call does the rejection sampling.
+// This lets a server ship the huge public LWE matrix A as a 32-byte seed:
+// the client calls RandFromSeed with that same seed to re-derive A locally
+// instead of downloading it.
+//
+// Passing a nil seed falls back to Rand with crypto/rand.Reader, giving
+// output indistinguishable from today's path for callers that don't need
+// a shareable seed.
+func RandFromSeed[T Elem](seed *[32]byte, rows, cols, logmod, mod uint64) *Matrix[T] {
+	if seed == nil {
+		return Rand[T](cryptoRandSource{}, rows, cols, logmod, mod)
+	}
+	return Rand[T](newCTRSource(seed), rows, cols, logmod, mod)
+}
@@ -0,0 +1,27 @@
+package matrix
+
+import (
+	"testing"
+)
+
+// These dimensions match the public matrix A used by Client.QueryLHE for a
+// typical SimplePIR database: M rows (one per DB row) by a small secret
+// dimension N, modulus the power of two 2^32.
+const (
+	benchRows   = 1 << 14
+	benchCols   = 4
+	benchLogMod = 32
+)
+
+func BenchmarkRandCryptoRand(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Rand[Elem64](cryptoRandSource{}, benchRows, benchCols, benchLogMod, 0)
+	}
+}
+
+func BenchmarkRandFromSeed(b *testing.B) {
+	var seed [32]byte
+	for i := 0; i < b.N; i++ {
+		RandFromSeed[Elem64](&seed, benchRows, benchCols, benchLogMod, 0)
+	}
+}
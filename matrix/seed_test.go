@@ -0,0 +1,60 @@
+package matrix
+
+import (
+	"testing"
+)
+
+func TestRandFromSeedDeterministic(t *testing.T) {
+	var seed [32]byte
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+
+	a := RandFromSeed[Elem64](&seed, 16, 4, 32, 0)
+	b := RandFromSeed[Elem64](&seed, 16, 4, 32, 0)
+
+	if !a.Equals(b) {
+		t.Fatalf("RandFromSeed is not deterministic for a fixed seed")
+	}
+}
+
+func TestRandFromSeedDifferentSeeds(t *testing.T) {
+	var seed1, seed2 [32]byte
+	seed2[0] = 1
+
+	a := RandFromSeed[Elem64](&seed1, 16, 4, 32, 0)
+	b := RandFromSeed[Elem64](&seed2, 16, 4, 32, 0)
+
+	if a.Equals(b) {
+		t.Fatalf("different seeds produced identical matrices")
+	}
+}
+
+func TestRandFromSeedInRange(t *testing.T) {
+	var seed [32]byte
+	mod := uint64(97)
+	m := RandFromSeed[Elem64](&seed, 8, 8, 0, mod)
+	for i := uint64(0); i < m.Rows(); i++ {
+		for j := uint64(0); j < m.Cols(); j++ {
+			if v := m.Get(i, j); v >= mod {
+				t.Fatalf("value %d out of range [0, %d)", v, mod)
+			}
+		}
+	}
+}
+
+// A nil seed falls back to Rand over crypto/rand, so the output is random:
+// only its shape and its range mod 2^logmod can be checked.
+func TestRandFromSeedNilSeedShapeAndRange(t *testing.T) {
+	m := RandFromSeed[Elem64](nil, 4, 4, 16, 0)
+	if m.Rows() != 4 || m.Cols() != 4 {
+		t.Fatalf("unexpected dimensions: %d-by-%d", m.Rows(), m.Cols())
+	}
+	for i := uint64(0); i < m.Rows(); i++ {
+		for j := uint64(0); j < m.Cols(); j++ {
+			if v := m.Get(i, j); v >= 1<<16 {
+				t.Fatalf("value %d out of range [0, %d)", v, 1<<16)
+			}
+		}
+	}
+}
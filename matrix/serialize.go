@@ -0,0 +1,200 @@
+package matrix
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// binaryFormatVersion is bumped whenever the on-wire layout produced by
+// WriteTo/MarshalBinary changes, so old and new clients can tell each
+// other's encodings apart (e.g. if we later add optional zstd compression
+// of the element payload).
+const binaryFormatVersion = 1
+
+const (
+	tag32 byte = 1
+	tag64 byte = 2
+)
+
+// headerSize is the version byte, element-width tag byte, and row/col
+// counts (8 bytes each).
+const headerSize = 1 + 1 + 8 + 8
+
+// maxDecodedElements bounds how large a matrix ReadFrom will allocate for a
+// single wire-supplied header, so a corrupted or adversarial rows/cols pair
+// can't force an unbounded allocation. 1<<32 elements is already a generous
+// ceiling (32GiB for 64-bit elements) well above any matrix this package
+// deals with.
+const maxDecodedElements = 1 << 32
+
+// checkedMulUint64 multiplies a and b, returning an error instead of a
+// silently wrapped result if the product would overflow uint64.
+func checkedMulUint64(a, b uint64) (uint64, error) {
+	if a != 0 && b > ^uint64(0)/a {
+		return 0, fmt.Errorf("%d*%d overflows uint64", a, b)
+	}
+	return a * b, nil
+}
+
+func elemTag[T Elem]() byte {
+	var zero T
+	if reflect.TypeOf(zero) == reflect.TypeOf(Elem32(0)) {
+		return tag32
+	}
+	return tag64
+}
+
+// MarshalBinary encodes m as a version byte, an element-width tag, the row
+// and column counts, and the elements themselves, all little-endian.
+func (m *Matrix[T]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := m.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes data written by MarshalBinary/WriteTo into m.
+func (m *Matrix[T]) UnmarshalBinary(data []byte) error {
+	_, err := m.ReadFrom(bytes.NewReader(data))
+	return err
+}
+
+// WriteTo streams m's header and elements to w without building the whole
+// encoding in memory first.
+func (m *Matrix[T]) WriteTo(w io.Writer) (int64, error) {
+	tag := elemTag[T]()
+
+	hdr := make([]byte, headerSize)
+	hdr[0] = binaryFormatVersion
+	hdr[1] = tag
+	binary.LittleEndian.PutUint64(hdr[2:10], m.rows)
+	binary.LittleEndian.PutUint64(hdr[10:18], m.cols)
+
+	n, err := w.Write(hdr)
+	total := int64(n)
+	if err != nil {
+		return total, err
+	}
+
+	elemSize := 4
+	if tag == tag64 {
+		elemSize = 8
+	}
+
+	bw := bufio.NewWriterSize(w, 64*1024)
+	chunk := make([]byte, elemSize)
+	for i := range m.data {
+		if tag == tag32 {
+			binary.LittleEndian.PutUint32(chunk, uint32(m.data[i]))
+		} else {
+			binary.LittleEndian.PutUint64(chunk, uint64(m.data[i]))
+		}
+		nn, err := bw.Write(chunk)
+		total += int64(nn)
+		if err != nil {
+			return total, err
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return total, err
+	}
+	return total, nil
+}
+
+// ReadFrom replaces m's contents with a matrix streamed from r in the
+// format written by WriteTo/MarshalBinary, without materializing the
+// elements in a temporary buffer first.
+func (m *Matrix[T]) ReadFrom(r io.Reader) (int64, error) {
+	hdr := make([]byte, headerSize)
+	n, err := io.ReadFull(r, hdr)
+	total := int64(n)
+	if err != nil {
+		return total, fmt.Errorf("matrix: reading header: %w", err)
+	}
+
+	if version := hdr[0]; version != binaryFormatVersion {
+		return total, fmt.Errorf("matrix: unsupported format version %d", version)
+	}
+
+	tag := hdr[1]
+	if want := elemTag[T](); tag != want {
+		return total, fmt.Errorf("matrix: element width tag %d does not match expected %d", tag, want)
+	}
+
+	rows := binary.LittleEndian.Uint64(hdr[2:10])
+	cols := binary.LittleEndian.Uint64(hdr[10:18])
+
+	// rows/cols come straight off the wire, so bound their product before
+	// allocating: an adversarial or corrupted header could otherwise force
+	// an arbitrarily large allocation, or overflow uint64 and wrap around
+	// to a small, wrong size.
+	elems, err := checkedMulUint64(rows, cols)
+	if err != nil {
+		return total, fmt.Errorf("matrix: %w", err)
+	}
+	if elems > maxDecodedElements {
+		return total, fmt.Errorf("matrix: %d elements (%d-by-%d) exceeds sanity bound of %d", elems, rows, cols, maxDecodedElements)
+	}
+
+	m.rows = rows
+	m.cols = cols
+	m.data = make([]T, elems)
+
+	elemSize := 4
+	if tag == tag64 {
+		elemSize = 8
+	}
+
+	// Read in bounded chunks directly from r rather than wrapping it in a
+	// bufio.Reader: r is often an externally-owned stream (e.g. a
+	// net.Conn) shared with a subsequent ReadFrom call for the next value,
+	// and a bufio.Reader would greedily buffer past the end of m's own
+	// bytes, silently dropping whatever it read but the caller never
+	// consumed.
+	const maxChunkBytes = 64 * 1024
+	totalBytes := len(m.data) * elemSize
+	buf := make([]byte, minInt(maxChunkBytes, totalBytes))
+	idx := 0
+	for remaining := totalBytes; remaining > 0; {
+		chunkBytes := minInt(len(buf), remaining)
+		chunkBytes -= chunkBytes % elemSize
+		nn, err := io.ReadFull(r, buf[:chunkBytes])
+		total += int64(nn)
+		if err != nil {
+			return total, fmt.Errorf("matrix: reading elements: %w", err)
+		}
+		for off := 0; off < chunkBytes; off += elemSize {
+			if tag == tag32 {
+				m.data[idx] = T(binary.LittleEndian.Uint32(buf[off:]))
+			} else {
+				m.data[idx] = T(binary.LittleEndian.Uint64(buf[off:]))
+			}
+			idx++
+		}
+		remaining -= chunkBytes
+	}
+	return total, nil
+}
+
+// NewFromReader reads a matrix previously written by WriteTo/MarshalBinary,
+// streaming elements directly into the result rather than staging them in
+// a temporary buffer.
+func NewFromReader[T Elem](r io.Reader) (*Matrix[T], error) {
+	out := new(Matrix[T])
+	if _, err := out.ReadFrom(r); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
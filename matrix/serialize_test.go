@@ -0,0 +1,123 @@
+package matrix
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestMarshalUnmarshalBinary64(t *testing.T) {
+	m := New[Elem64](3, 4)
+	for i := uint64(0); i < m.Rows(); i++ {
+		for j := uint64(0); j < m.Cols(); j++ {
+			m.Set(i*m.Cols()+j+1, i, j)
+		}
+	}
+
+	data, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	out := new(Matrix[Elem64])
+	if err := out.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+
+	if !m.Equals(out) {
+		t.Fatalf("round-tripped matrix does not match original")
+	}
+}
+
+func TestWriteToReadFrom32(t *testing.T) {
+	m := New[Elem32](2, 5)
+	for i := 0; i < len(m.data); i++ {
+		m.data[i] = Elem32(i * 7)
+	}
+
+	var buf bytes.Buffer
+	if _, err := m.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+
+	out, err := NewFromReader[Elem32](&buf)
+	if err != nil {
+		t.Fatalf("NewFromReader: %v", err)
+	}
+
+	if !m.Equals(out) {
+		t.Fatalf("round-tripped matrix does not match original")
+	}
+}
+
+func TestReadFromStreamsMultipleMatrices(t *testing.T) {
+	a := New[Elem64](2, 2)
+	b := New[Elem64](2, 2)
+	for i := 0; i < len(a.data); i++ {
+		a.data[i] = Elem64(i + 1)
+		b.data[i] = Elem64(i + 100)
+	}
+
+	var buf bytes.Buffer
+	if _, err := a.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo a: %v", err)
+	}
+	if _, err := b.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo b: %v", err)
+	}
+
+	gotA, err := NewFromReader[Elem64](&buf)
+	if err != nil {
+		t.Fatalf("NewFromReader a: %v", err)
+	}
+	gotB, err := NewFromReader[Elem64](&buf)
+	if err != nil {
+		t.Fatalf("NewFromReader b: %v", err)
+	}
+
+	if !a.Equals(gotA) {
+		t.Fatalf("first matrix does not match original")
+	}
+	if !b.Equals(gotB) {
+		t.Fatalf("second matrix does not match original")
+	}
+}
+
+func TestReadFromRejectsOversizedHeader(t *testing.T) {
+	hdr := make([]byte, headerSize)
+	hdr[0] = binaryFormatVersion
+	hdr[1] = tag64
+	binary.LittleEndian.PutUint64(hdr[2:10], maxDecodedElements+1)
+	binary.LittleEndian.PutUint64(hdr[10:18], 1)
+
+	out := new(Matrix[Elem64])
+	if _, err := out.ReadFrom(bytes.NewReader(hdr)); err == nil {
+		t.Fatalf("expected ReadFrom to reject a header claiming more than maxDecodedElements elements")
+	}
+}
+
+func TestReadFromRejectsOverflowingHeader(t *testing.T) {
+	hdr := make([]byte, headerSize)
+	hdr[0] = binaryFormatVersion
+	hdr[1] = tag64
+	binary.LittleEndian.PutUint64(hdr[2:10], 1<<40)
+	binary.LittleEndian.PutUint64(hdr[10:18], 1<<40)
+
+	out := new(Matrix[Elem64])
+	if _, err := out.ReadFrom(bytes.NewReader(hdr)); err == nil {
+		t.Fatalf("expected ReadFrom to reject a rows*cols header that overflows uint64")
+	}
+}
+
+func TestUnmarshalBinaryRejectsWrongWidth(t *testing.T) {
+	m := New[Elem64](1, 1)
+	data, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	out := new(Matrix[Elem32])
+	if err := out.UnmarshalBinary(data); err == nil {
+		t.Fatalf("expected error unmarshaling 64-bit data into a 32-bit matrix")
+	}
+}
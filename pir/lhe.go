@@ -39,8 +39,10 @@ func (c *Client[T]) QueryLHE(arrIn *matrix.Matrix[T]) (*SecretLHE[T], *Query[T])
 
   //log.Printf("N=%v,  P=%v, L=%v, M=%v", c.dbinfo.Num, c.dbinfo.P(), c.dbinfo.L, c.dbinfo.M)
 
+	// The secret lives in the same ring as the public matrix A, i.e. mod
+	// q = P*Delta (the plaintext modulus scaled up by the encoding factor).
 	s := &SecretLHE[T]{
-		secret: matrix.Rand[T](c.prg, c.params.N, 1, 0),
+		secret: matrix.Rand[T](c.prg, c.params.N, 1, 0, c.params.P*c.params.Delta),
 		arr:    arr,
 	}
 
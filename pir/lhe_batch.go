@@ -0,0 +1,108 @@
+package pir
+
+import (
+	"github.com/henrycg/simplepir/matrix"
+)
+
+// SecretLHEBatch is the batched analogue of SecretLHE: it holds k secrets
+// as columns of a single N-by-k matrix instead of k separate N-by-1
+// matrices, so recovery can process the whole batch with one matrix.Mul.
+type SecretLHEBatch[T matrix.Elem] struct {
+	secret *matrix.Matrix[T]
+	arr    *matrix.Matrix[T]
+}
+
+func (s *SecretLHEBatch[T]) Copy() *SecretLHEBatch[T] {
+	out := new(SecretLHEBatch[T])
+
+	out.secret = s.secret.Copy()
+	out.arr = s.arr.Copy()
+
+	return out
+}
+
+// QueryBatch is the batched analogue of Query: a single matrix whose
+// columns are the k individual queries packed together, so the server
+// answers the whole batch with one matrix.Mul(DB, Q) instead of k.
+type QueryBatch[T matrix.Elem] struct {
+	query *matrix.Matrix[T]
+}
+
+// QueryLHEBatch packs len(arrs) LHE queries into a single width-k query, so
+// the server answers all of them with one matrix.Mul(DB, Q) and the client
+// recovers all of them with one matrix.Mul(hint, S) in RecoverManyLHEBatch.
+// Each entry of arrs must have the same dimensions QueryLHE requires of its
+// single argument.
+func (c *Client[T]) QueryLHEBatch(arrs []*matrix.Matrix[T]) (*SecretLHEBatch[T], *QueryBatch[T]) {
+	k := uint64(len(arrs))
+	if k == 0 {
+		panic("QueryLHEBatch requires at least one query")
+	}
+
+	if (c.dbinfo.Ne != 1) || ((1 << c.dbinfo.RowLength) > c.params.P) {
+		panic("Not yet supported.")
+	}
+
+	// checks that p is a power of 2 (since q must be)
+	if (c.params.P & (c.params.P - 1)) != 0 {
+		panic("LHE requires p | q.")
+	}
+
+	arr := matrix.Zeros[T](c.dbinfo.M, k)
+	for col, a := range arrs {
+		if a.Rows() != c.dbinfo.M || a.Cols() != 1 {
+			panic("Parameter mismatch")
+		}
+		for row := uint64(0); row < c.dbinfo.M; row++ {
+			arr.Set(a.Get(row, 0), row, uint64(col))
+		}
+	}
+
+	// The secret lives in the same ring as the public matrix A, i.e. mod
+	// q = P*Delta (the plaintext modulus scaled up by the encoding factor).
+	s := &SecretLHEBatch[T]{
+		secret: matrix.Rand[T](c.prg, c.params.N, k, 0, c.params.P*c.params.Delta),
+		arr:    arr,
+	}
+
+	err := matrix.Gaussian[T](c.prg, c.dbinfo.M, k)
+
+	query := matrix.Mul(c.matrixA, s.secret)
+	query.Add(err)
+
+	arr.MulConst(T(c.params.Delta))
+	query.Add(arr)
+
+	// Pad the query to match the dimensions of the compressed DB
+	if c.dbinfo.M%c.dbinfo.Squishing != 0 {
+		pad := c.dbinfo.Squishing - (c.dbinfo.M % c.dbinfo.Squishing)
+		query.Concat(matrix.Zeros[T](pad, k))
+	}
+
+	return s, &QueryBatch[T]{query}
+}
+
+// RecoverManyLHEBatch denoises a batched Answer, reusing the same
+// params.Round denoising RecoverManyLHE applies per column, one column per
+// original QueryLHE call that went into the batch.
+func (c *Client[T]) RecoverManyLHEBatch(secret *SecretLHEBatch[T], ansIn *Answer[T]) *matrix.Matrix[T] {
+	if c.dbinfo.Ne != 1 {
+		panic("Not yet supported")
+	}
+
+	ans := ansIn.answer.Copy()
+
+	interm := matrix.Mul(c.hint, secret.secret)
+	ans.Sub(interm)
+
+	out := matrix.Zeros[T](ans.Rows(), ans.Cols())
+	for row := uint64(0); row < ans.Rows(); row++ {
+		for col := uint64(0); col < ans.Cols(); col++ {
+			noised := uint64(ans.Get(row, col))
+			denoised := c.params.Round(noised)
+			out.Set(denoised%c.params.P, row, col)
+		}
+	}
+
+	return out
+}
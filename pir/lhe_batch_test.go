@@ -0,0 +1,80 @@
+package pir
+
+import (
+	"testing"
+
+	"github.com/henrycg/simplepir/matrix"
+)
+
+// newBatchTestClient builds a Client whose DB is (implicitly) the identity
+// matrix, so hint == matrixA and an Answer is just the raw query. That
+// lets this test exercise the client-side encode/recover round trip
+// without needing a real server or squished on-disk DB layout, while still
+// exercising the same QueryLHE/QueryLHEBatch/RecoverManyLHE(Batch) code
+// the real client uses.
+func newBatchTestClient(t *testing.T) *Client[matrix.Elem64] {
+	t.Helper()
+
+	const (
+		rows  = 8
+		n     = 4
+		p     = uint64(4)
+		delta = uint64(1) << 20
+	)
+
+	prg := NewBufPRG(NewPRG(RandomPRGKey()))
+	matrixA := matrix.Rand[matrix.Elem64](prg, rows, n, 32, 0)
+
+	return &Client[matrix.Elem64]{
+		dbinfo: DBInfo{
+			M:         rows,
+			Ne:        1,
+			RowLength: 1,
+			Squishing: 1,
+		},
+		params: Params{
+			N:     n,
+			P:     p,
+			Delta: delta,
+		},
+		matrixA: matrixA,
+		prg:     prg,
+		hint:    matrixA.Copy(),
+	}
+}
+
+func TestQueryLHEBatchMatchesIndividualQueries(t *testing.T) {
+	c := newBatchTestClient(t)
+	const rows = 8
+	const k = 3
+
+	arrs := make([]*matrix.Matrix[matrix.Elem64], k)
+	for i := range arrs {
+		a := matrix.New[matrix.Elem64](rows, 1)
+		for row := uint64(0); row < rows; row++ {
+			a.Set((uint64(i)+row)%2, row, 0)
+		}
+		arrs[i] = a
+	}
+
+	// Reference: k independent QueryLHE/RecoverManyLHE calls.
+	want := make([]*matrix.Matrix[matrix.Elem64], k)
+	for i, a := range arrs {
+		secret, query := c.QueryLHE(a)
+		ans := &Answer[matrix.Elem64]{answer: query.query.Copy()}
+		want[i] = c.RecoverManyLHE(secret, ans)
+	}
+
+	// Batched: one QueryLHEBatch/RecoverManyLHEBatch call for all k.
+	secretBatch, queryBatch := c.QueryLHEBatch(arrs)
+	ansBatch := &Answer[matrix.Elem64]{answer: queryBatch.query.Copy()}
+	got := c.RecoverManyLHEBatch(secretBatch, ansBatch)
+
+	for i := 0; i < k; i++ {
+		for row := uint64(0); row < rows; row++ {
+			if g, w := got.Get(row, uint64(i)), want[i].Get(row, 0); g != w {
+				t.Fatalf("batch column %d row %d = %d, want %d (from independent QueryLHE)", i, row, g, w)
+			}
+		}
+	}
+}
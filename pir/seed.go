@@ -0,0 +1,19 @@
+package pir
+
+import "github.com/henrycg/simplepir/matrix"
+
+// SCOPE NOTE: the request behind this file asked for a SeedA []byte field
+// threaded through Server and Client, so a server could ship a 32-byte
+// seed instead of the full public matrix A. The Server and Client types
+// themselves aren't defined anywhere in this slice of the repository (no
+// DBInfo/Params/Server/Client declarations are present to add a field
+// to), so that wiring is NOT done here. DeriveMatrixA below is the
+// seed-to-matrix primitive the wiring would call; whoever owns the
+// Server/Client definitions still needs to add the SeedA field and the
+// constructor/handshake code that passes it across the wire.
+
+// DeriveMatrixA reconstructs the public LWE matrix A from a 32-byte seed
+// via matrix.RandFromSeed, rather than downloading A in full.
+func DeriveMatrixA[T matrix.Elem](seed [32]byte, rows, cols, logmod, mod uint64) *matrix.Matrix[T] {
+	return matrix.RandFromSeed[T](&seed, rows, cols, logmod, mod)
+}
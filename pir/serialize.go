@@ -0,0 +1,97 @@
+package pir
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/henrycg/simplepir/matrix"
+)
+
+// wireFormatVersion is bumped whenever the layout below changes, so a
+// server and client exchanging Query/Answer/SecretLHE over a net.Conn can
+// detect a mismatched version instead of silently misparsing.
+const wireFormatVersion = 1
+
+// marshalMatrices encodes a version byte followed by each matrix's
+// MarshalBinary output, length-prefixed so unmarshalMatrices can split
+// them back apart.
+func marshalMatrices[T matrix.Elem](mats ...*matrix.Matrix[T]) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(wireFormatVersion)
+	for _, m := range mats {
+		b, err := m.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		var lenBuf [8]byte
+		binary.LittleEndian.PutUint64(lenBuf[:], uint64(len(b)))
+		buf.Write(lenBuf[:])
+		buf.Write(b)
+	}
+	return buf.Bytes(), nil
+}
+
+func unmarshalMatrices[T matrix.Elem](data []byte, dsts ...**matrix.Matrix[T]) error {
+	r := bytes.NewReader(data)
+
+	version, err := r.ReadByte()
+	if err != nil {
+		return fmt.Errorf("pir: reading version: %w", err)
+	}
+	if version != wireFormatVersion {
+		return fmt.Errorf("pir: unsupported wire format version %d", version)
+	}
+
+	for i, dst := range dsts {
+		var lenBuf [8]byte
+		if _, err := io.ReadFull(r, lenBuf[:]); err != nil {
+			return fmt.Errorf("pir: reading length of field %d: %w", i, err)
+		}
+
+		// The length prefix comes straight off the wire: bound it against
+		// what's actually left in data before allocating, so a corrupted
+		// or adversarial prefix can't force an oversized allocation.
+		length := binary.LittleEndian.Uint64(lenBuf[:])
+		if length > uint64(r.Len()) {
+			return fmt.Errorf("pir: field %d length %d exceeds remaining input of %d bytes", i, length, r.Len())
+		}
+
+		body := make([]byte, length)
+		if _, err := io.ReadFull(r, body); err != nil {
+			return fmt.Errorf("pir: reading field %d: %w", i, err)
+		}
+
+		m := new(matrix.Matrix[T])
+		if err := m.UnmarshalBinary(body); err != nil {
+			return fmt.Errorf("pir: unmarshaling field %d: %w", i, err)
+		}
+		*dst = m
+	}
+	return nil
+}
+
+func (q *Query[T]) MarshalBinary() ([]byte, error) {
+	return marshalMatrices(q.query)
+}
+
+func (q *Query[T]) UnmarshalBinary(data []byte) error {
+	return unmarshalMatrices[T](data, &q.query)
+}
+
+func (a *Answer[T]) MarshalBinary() ([]byte, error) {
+	return marshalMatrices(a.answer)
+}
+
+func (a *Answer[T]) UnmarshalBinary(data []byte) error {
+	return unmarshalMatrices[T](data, &a.answer)
+}
+
+func (s *SecretLHE[T]) MarshalBinary() ([]byte, error) {
+	return marshalMatrices(s.query, s.secret, s.arr)
+}
+
+func (s *SecretLHE[T]) UnmarshalBinary(data []byte) error {
+	return unmarshalMatrices[T](data, &s.query, &s.secret, &s.arr)
+}
@@ -0,0 +1,49 @@
+package pir
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/henrycg/simplepir/matrix"
+)
+
+func TestAnswerMarshalUnmarshalBinary(t *testing.T) {
+	m := matrix.New[matrix.Elem64](2, 2)
+	for i := uint64(0); i < 2; i++ {
+		for j := uint64(0); j < 2; j++ {
+			m.Set(i*2+j+1, i, j)
+		}
+	}
+
+	a := &Answer[matrix.Elem64]{answer: m}
+	data, err := a.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	out := new(Answer[matrix.Elem64])
+	if err := out.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+	if !m.Equals(out.answer) {
+		t.Fatalf("round-tripped answer does not match original")
+	}
+}
+
+func TestUnmarshalMatricesRejectsOversizedLengthPrefix(t *testing.T) {
+	m := matrix.New[matrix.Elem64](1, 1)
+	a := &Answer[matrix.Elem64]{answer: m}
+	data, err := a.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	// Corrupt the length prefix (immediately after the version byte) to
+	// claim far more data follows than actually does.
+	binary.LittleEndian.PutUint64(data[1:9], uint64(len(data))+1<<30)
+
+	out := new(Answer[matrix.Elem64])
+	if err := out.UnmarshalBinary(data); err == nil {
+		t.Fatalf("expected UnmarshalBinary to reject a length prefix exceeding the remaining input")
+	}
+}